@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"regexp"
 )
 
 // StartBufSize is the default starting size of the buffer used to scan and parse multi-statement migrations
@@ -44,3 +45,32 @@ func Parse(reader io.Reader, delimiter []byte, maxMigrationSize int, h Handler)
 	}
 	return scanner.Err()
 }
+
+// ParseRegexp parses the given multi-statement migration, splitting it after every match of delimiter.
+// Unlike Parse, the whole migration is read before splitting so that matches never depend on how the
+// reader chunks its data. As a result maxMigrationSize limits the size of the entire migration rather
+// than of a single statement. Empty matches of delimiter are ignored.
+func ParseRegexp(reader io.Reader, delimiter *regexp.Regexp, maxMigrationSize int, h Handler) error {
+	migr, err := io.ReadAll(io.LimitReader(reader, int64(maxMigrationSize)+1))
+	if err != nil {
+		return err
+	}
+	if len(migr) > maxMigrationSize {
+		return bufio.ErrTooLong
+	}
+
+	start := 0
+	for _, loc := range delimiter.FindAllIndex(migr, -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		if !h(migr[start:loc[1]]) {
+			return nil
+		}
+		start = loc[1]
+	}
+	if start < len(migr) {
+		h(migr[start:])
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package multistmt_test
 
 import (
+	"bufio"
+	"io"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -42,6 +45,41 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseMultiReader(t *testing.T) {
+	testCases := []struct {
+		name      string
+		parts     []string
+		delimiter string
+		expected  []string
+	}{
+		{name: "delimiter at end of first reader", parts: []string{"statement one;", " statement two;"}, delimiter: ";",
+			expected: []string{"statement one;", " statement two;"}},
+		{name: "delimiter at start of second reader", parts: []string{"statement one", "; statement two;"}, delimiter: ";",
+			expected: []string{"statement one;", " statement two;"}},
+		{name: "statement spans readers", parts: []string{"statement ", "one; statement ", "two;"}, delimiter: ";",
+			expected: []string{"statement one;", " statement two;"}},
+		{name: "delimiter split across readers", parts: []string{"statement one\nG", "O\nstatement two\nGO\n"}, delimiter: "\nGO\n",
+			expected: []string{"statement one\nGO\n", "statement two\nGO\n"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			readers := make([]io.Reader, 0, len(tc.parts))
+			for _, p := range tc.parts {
+				readers = append(readers, strings.NewReader(p))
+			}
+
+			stmts := make([]string, 0, len(tc.expected))
+			err := multistmt.Parse(io.MultiReader(readers...), []byte(tc.delimiter), maxMigrationSize, func(b []byte) bool {
+				stmts = append(stmts, string(b))
+				return true
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, stmts)
+		})
+	}
+}
+
 func TestParseDiscontinue(t *testing.T) {
 	multiStmt := "statement one; statement two"
 	delimiter := ";"
@@ -55,3 +93,52 @@ func TestParseDiscontinue(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, stmts)
 }
+
+func TestParseRegexp(t *testing.T) {
+	testCases := []struct {
+		name        string
+		multiStmt   string
+		delimiter   string
+		expected    []string
+		expectedErr error
+	}{
+		{name: "single statement, no delimiter", multiStmt: "single statement, no delimiter", delimiter: `;`,
+			expected: []string{"single statement, no delimiter"}, expectedErr: nil},
+		{name: "single statement, one delimiter", multiStmt: "single statement, one delimiter;", delimiter: `;`,
+			expected: []string{"single statement, one delimiter;"}, expectedErr: nil},
+		{name: "two statements, no trailing delimiter", multiStmt: "statement one; statement two", delimiter: `;`,
+			expected: []string{"statement one;", " statement two"}, expectedErr: nil},
+		{name: "delimiter at end of line only", multiStmt: "SELECT ';';\nSELECT 2;\n", delimiter: `(?m);$`,
+			expected: []string{"SELECT ';';", "\nSELECT 2;", "\n"}, expectedErr: nil},
+		{name: "empty matches are ignored", multiStmt: "statement one;; statement two", delimiter: `;*`,
+			expected: []string{"statement one;;", " statement two"}, expectedErr: nil},
+		{name: "migration too large", multiStmt: strings.Repeat("x", maxMigrationSize+1), delimiter: `;`,
+			expected: []string{}, expectedErr: bufio.ErrTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stmts := make([]string, 0, len(tc.expected))
+			err := multistmt.ParseRegexp(strings.NewReader(tc.multiStmt), regexp.MustCompile(tc.delimiter), maxMigrationSize, func(b []byte) bool {
+				stmts = append(stmts, string(b))
+				return true
+			})
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, stmts)
+		})
+	}
+}
+
+func TestParseRegexpDiscontinue(t *testing.T) {
+	multiStmt := "statement one; statement two"
+	delimiter := regexp.MustCompile(`;`)
+	expected := []string{"statement one;"}
+
+	stmts := make([]string, 0, len(expected))
+	err := multistmt.ParseRegexp(strings.NewReader(multiStmt), delimiter, maxMigrationSize, func(b []byte) bool {
+		stmts = append(stmts, string(b))
+		return false
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, stmts)
+}
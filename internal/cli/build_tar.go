@@ -0,0 +1,7 @@
+//go:build tar
+
+package cli
+
+import (
+	_ "github.com/golang-migrate/migrate/v4/source/tar"
+)
@@ -0,0 +1,56 @@
+//go:build go1.16
+
+package iofs
+
+import (
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// FilterExtension wraps an io/fs#FS so that directory listings only contain
+// files whose names end with ext, e.g. ".ydb.sql". Directories are always
+// listed. It allows a single embed.FS holding per-engine variants of the
+// same migrations to be shared between several database drivers:
+//
+//	d, err := iofs.New(iofs.FilterExtension(fs, ".ydb.sql"), "migrations")
+//
+// Note that ext is matched as a plain suffix, so ".sql" matches ".ydb.sql"
+// files as well.
+func FilterExtension(fsys fs.FS, ext string) fs.FS {
+	return &extFS{fsys: fsys, ext: ext}
+}
+
+type extFS struct {
+	fsys fs.FS
+	ext  string
+}
+
+// Open implements fs.FS.
+func (e *extFS) Open(name string) (fs.File, error) {
+	return e.fsys.Open(name)
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (e *extFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(e.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	filtered := entries[:0]
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), e.ext) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+// Close closes the wrapped file system if possible.
+func (e *extFS) Close() error {
+	c, ok := e.fsys.(io.Closer)
+	if !ok {
+		return nil
+	}
+	return c.Close()
+}
@@ -0,0 +1,55 @@
+//go:build go1.16
+
+package iofs_test
+
+import (
+	"io"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	st "github.com/golang-migrate/migrate/v4/source/testing"
+)
+
+func TestFilterExtension(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for _, name := range []string{
+		"1_foobar.up", "1_foobar.down",
+		"3_foobar.up",
+		"4_foobar.up", "4_foobar.down",
+		"5_foobar.down",
+		"7_foobar.up", "7_foobar.down",
+	} {
+		fsys["migrations/"+name+".sql"] = &fstest.MapFile{Data: []byte("generic")}
+		fsys["migrations/"+name+".ydb.sql"] = &fstest.MapFile{Data: []byte("ydb")}
+	}
+
+	// without filtering, both variants of each migration clash
+	if _, err := iofs.New(fsys, "migrations"); err == nil {
+		t.Fatal("expected duplicate migration error")
+	}
+
+	d, err := iofs.New(iofs.FilterExtension(fsys, ".ydb.sql"), "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st.Test(t, d)
+
+	r, _, err := d.ReadUp(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	body, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "ydb" {
+		t.Fatalf("expected ydb variant, got %q", body)
+	}
+}
@@ -0,0 +1,123 @@
+package tar
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	nurl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"testing/fstest"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+func init() {
+	source.Register("tar", &Tar{})
+}
+
+// Tar reads migrations from the root of a tar or tar.gz archive.
+type Tar struct {
+	iofs.PartialDriver
+	url  string
+	path string
+}
+
+func (t *Tar) Open(url string) (source.Driver, error) {
+	p, err := parseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	fsys, err := NewFS(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", p, err)
+	}
+
+	nt := &Tar{
+		url:  url,
+		path: p,
+	}
+	if err := nt.Init(fsys, "."); err != nil {
+		return nil, err
+	}
+	return nt, nil
+}
+
+// NewFS reads a tar archive, optionally gzip-compressed, into an in-memory
+// io/fs#FS. The result can be passed to iofs.New to read migrations from any
+// directory inside the archive.
+func NewFS(r io.Reader) (fs.FS, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = zr.Close()
+		}()
+		r = zr
+	} else {
+		r = br
+	}
+
+	fsys := make(fstest.MapFS)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if !fs.ValidPath(name) {
+			return nil, fmt.Errorf("invalid path in archive: %s", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		fsys[name] = &fstest.MapFile{
+			Data:    data,
+			Mode:    hdr.FileInfo().Mode(),
+			ModTime: hdr.ModTime,
+		}
+	}
+	return fsys, nil
+}
+
+func parseURL(url string) (string, error) {
+	u, err := nurl.Parse(url)
+	if err != nil {
+		return "", err
+	}
+	// concat host and path to restore full path
+	// host might be `.`
+	p := u.Opaque
+	if len(p) == 0 {
+		p = u.Host + u.Path
+	}
+	if len(p) == 0 {
+		return "", errors.New("no archive path")
+	}
+	return filepath.Abs(p)
+}
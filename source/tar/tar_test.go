@@ -0,0 +1,119 @@
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	st "github.com/golang-migrate/migrate/v4/source/testing"
+)
+
+const scheme = "tar://"
+
+var files = map[string]string{
+	"1_foobar.up.sql":   "1 up",
+	"1_foobar.down.sql": "1 down",
+	"3_foobar.up.sql":   "3 up",
+	"4_foobar.up.sql":   "4 up",
+	"4_foobar.down.sql": "4 down",
+	"5_foobar.down.sql": "5 down",
+	"7_foobar.up.sql":   "7 up",
+	"7_foobar.down.sql": "7 down",
+}
+
+func Test(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "migrations.tar")
+	mustWriteFile(t, p, mustTar(t, "", files))
+
+	tr := &Tar{}
+	d, err := tr.Open(scheme + p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st.Test(t, d)
+}
+
+func TestGzip(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "migrations.tar.gz")
+	mustWriteFile(t, p, mustGzip(t, mustTar(t, "./", files)))
+
+	tr := &Tar{}
+	d, err := tr.Open(scheme + p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st.Test(t, d)
+}
+
+func TestNewFSWithIofs(t *testing.T) {
+	fsys, err := NewFS(bytes.NewReader(mustGzip(t, mustTar(t, "migrations/", files))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := iofs.New(fsys, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st.Test(t, d)
+}
+
+func TestOpenWithoutPath(t *testing.T) {
+	tr := &Tar{}
+	if _, err := tr.Open(scheme); err == nil {
+		t.Fatal("expected err for missing archive path")
+	}
+}
+
+func TestNewFSRejectsInvalidPath(t *testing.T) {
+	if _, err := NewFS(bytes.NewReader(mustTar(t, "../", files))); err == nil {
+		t.Fatal("expected err for path outside the archive root")
+	}
+}
+
+func mustTar(t *testing.T, prefix string, files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, body := range files {
+		hdr := &tar.Header{
+			Name: prefix + name,
+			Mode: 0644,
+			Size: int64(len(body)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(tw, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func mustGzip(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func mustWriteFile(t testing.TB, p string, data []byte) {
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
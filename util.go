@@ -61,3 +61,16 @@ func FilterCustomQuery(u *nurl.URL) *nurl.URL {
 	ux.RawQuery = vx.Encode()
 	return &ux
 }
+
+// FilterCustomQueryKeys filters only the given `x-` query values, passing any
+// other `x-` values through. It is meant for drivers whose underlying
+// connector consumes `x-` prefixed options of its own.
+func FilterCustomQueryKeys(u *nurl.URL, keys ...string) *nurl.URL {
+	ux := *u
+	vx := ux.Query()
+	for _, k := range keys {
+		vx.Del(k)
+	}
+	ux.RawQuery = vx.Encode()
+	return &ux
+}
@@ -33,3 +33,20 @@ func TestFilterCustomQuery(t *testing.T) {
 		t.Fatalf("expected ok=y, got %v", nx.Get("ok"))
 	}
 }
+
+func TestFilterCustomQueryKeys(t *testing.T) {
+	n, err := nurl.Parse("foo://host?a=b&x-custom=foo&x-connector=bar&ok=y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nx := FilterCustomQueryKeys(n, "x-custom").Query()
+	if nx.Get("x-custom") != "" {
+		t.Fatalf("didn't expect x-custom")
+	}
+	if nx.Get("x-connector") != "bar" {
+		t.Fatalf("expected x-connector=bar, got %v", nx.Get("x-connector"))
+	}
+	if nx.Get("ok") != "y" {
+		t.Fatalf("expected ok=y, got %v", nx.Get("ok"))
+	}
+}